@@ -5,33 +5,96 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"math/rand"
 	"net/http"
 	"os"
 	"os/signal"
 	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
 	httpui "github.com/devang/go-task-queue/internal/http"
+	"github.com/devang/go-task-queue/internal/hook"
 	"github.com/devang/go-task-queue/internal/metrics"
 	"github.com/devang/go-task-queue/internal/queue"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
+// configuredQueues returns the queue names workers, the delayed-job poller,
+// and the admin/DLQ views all operate on. Defaults to DefaultQueueName;
+// set QUEUES to a comma-separated list (e.g. "default,reports") to drain
+// additional named queues.
+func configuredQueues() []string {
+	raw := os.Getenv("QUEUES")
+	if raw == "" {
+		return []string{queue.DefaultQueueName}
+	}
+
+	var out []string
+	for _, name := range strings.Split(raw, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			out = append(out, name)
+		}
+	}
+	if len(out) == 0 {
+		return []string{queue.DefaultQueueName}
+	}
+	return out
+}
+
+func isKnownQueue(name string, queues []string) bool {
+	for _, q := range queues {
+		if q == name {
+			return true
+		}
+	}
+	return false
+}
+
 func main() {
+	queues := configuredQueues()
+
 	q := queue.NewQueue(10)
 	redisQueue := queue.NewRedisQueue()
-	redisQueue.StartDelayedJobPoller()
+	q.AttachRedisQueue(redisQueue)
+
+	// Demo handler so the result-storage feature is actually exercised:
+	// simulates work like processJob's built-in fallback does, then persists a
+	// result payload through rw for /result to serve back.
+	q.SetHandler(func(job queue.Job, rw *queue.ResultWriter) error {
+		workTime := time.Duration(rand.Intn(4)+1) * time.Second
+		time.Sleep(workTime)
+
+		if rand.Float32() < 0.3 {
+			return fmt.Errorf("simulated failure")
+		}
+
+		result := []byte(fmt.Sprintf("job %d (%s) completed at %s", job.ID, job.Name, time.Now().Format(time.RFC3339)))
+		return rw.Write(result)
+	})
+
+	if err := redisQueue.MigrateLegacySchema(); err != nil {
+		fmt.Println("⚠️  legacy schema migration failed:", err)
+	}
+
+	redisQueue.StartDelayedJobPoller(queues)
+	redisQueue.StartPeriodicScheduler()
+	redisQueue.StartReaper(10*time.Second, 30*time.Second)
+
+	hookPool := hook.NewPool(redisQueue.Client(), 5, 5*time.Second)
+	hookPool.Start()
 
 	for i := 1; i <= 3; i++ {
-		q.StartWorkerWithRedis(i, redisQueue)
+		q.StartWorkerWithRedis(i, redisQueue, queues)
 	}
 
 	metrics.Register()
 
 	mux := http.NewServeMux()
-	httpui.RegisterAdminRoutes(mux, redisQueue)
+	httpui.RegisterAdminRoutes(mux, redisQueue, queues)
 	httpui.RegisterAdminActions(mux, redisQueue)
+	httpui.RegisterPeriodicRoutes(mux, redisQueue)
 
 	// Expose Prometheus metrics
 	mux.Handle("/metrics", promhttp.HandlerFor(metrics.Registry, promhttp.HandlerOpts{}))
@@ -42,9 +105,23 @@ func main() {
 		if name == "" {
 			name = "Generic Job"
 		}
+
+		qn := r.URL.Query().Get("queue")
+		if qn != "" && !isKnownQueue(qn, queues) {
+			http.Error(w, fmt.Sprintf("unknown queue %q: no worker is configured to drain it (set QUEUES to add it)", qn), http.StatusBadRequest)
+			return
+		}
+
 		job := q.Enqueue(name)
+		job.StatusHookURL = r.URL.Query().Get("status_hook_url")
+		if qn != "" {
+			job.Queue = qn
+		}
+		if secs, err := strconv.Atoi(r.URL.Query().Get("retention")); err == nil {
+			job.Retention = time.Duration(secs) * time.Second
+		}
 		redisQueue.Enqueue(job)
-		redisQueue.SetJobStatus(job.ID, "QUEUED")
+		redisQueue.SetJobStatus(job, "QUEUED")
 		fmt.Fprintf(w, "Job %d enqueued successfully\n", job.ID)
 	})
 
@@ -83,14 +160,67 @@ func main() {
 		}
 	})
 
+	// Fetch a chain's aggregated status
+	mux.HandleFunc("/chain", func(w http.ResponseWriter, r *http.Request) {
+		id := r.URL.Query().Get("id")
+		if id == "" {
+			http.Error(w, "missing id", http.StatusBadRequest)
+			return
+		}
+
+		chain, err := redisQueue.GetChain(id)
+		if err != nil {
+			http.Error(w, "chain not found", http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(chain)
+	})
+
+	// Fetch a group's aggregated status
+	mux.HandleFunc("/group", func(w http.ResponseWriter, r *http.Request) {
+		id := r.URL.Query().Get("id")
+		if id == "" {
+			http.Error(w, "missing id", http.StatusBadRequest)
+			return
+		}
+
+		group, err := redisQueue.GetGroup(id)
+		if err != nil {
+			http.Error(w, "group not found", http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(group)
+	})
+
+	// Fetch a stored job result
+	mux.HandleFunc("/result", func(w http.ResponseWriter, r *http.Request) {
+		idStr := r.URL.Query().Get("id")
+		if idStr == "" {
+			http.Error(w, "missing id", http.StatusBadRequest)
+			return
+		}
+
+		id, _ := strconv.ParseInt(idStr, 10, 64)
+
+		data, err := redisQueue.GetResult(id)
+		if err != nil {
+			http.Error(w, "result not found or expired", http.StatusNotFound)
+			return
+		}
+
+		w.Write(data)
+	})
+
 	// Dead Letter Queue (failed jobs)
 	mux.HandleFunc("/dlq", func(w http.ResponseWriter, r *http.Request) {
-		jobs, _ := redisQueue.Client().LRange(redisQueue.Ctx(), "dead_letter_queue", 0, 20).Result()
+		jobs, _ := redisQueue.ListDLQ(queues, 20)
 
 		fmt.Fprintf(w, "---- Dead Letter Queue ----\n")
-		for _, data := range jobs {
-			var job queue.Job
-			json.Unmarshal([]byte(data), &job)
+		for _, job := range jobs {
 			fmt.Fprintf(w, "Job %d (%s), retries: %d\n", job.ID, job.Name, job.Retries)
 		}
 	})
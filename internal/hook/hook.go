@@ -0,0 +1,229 @@
+// Package hook delivers job status-change notifications to per-job HTTP
+// callback URLs, with retry, backoff, and a dead-letter list for callbacks
+// that never succeed.
+package hook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	eventKeyPrefix      = "hook_events:"
+	processingKeyPrefix = "hook_processing:"
+	deadEventsKey       = "hook_dead_events"
+	delayedEventsKey    = "hook_delayed_events"
+	maxAttempts         = 5
+)
+
+// popDueDelayedEventsScript atomically pops every entry in delayedEventsKey
+// whose backoff has elapsed (score <= ARGV[1]), so a retry sitting in its
+// backoff window lives in Redis the whole time and survives a crash,
+// instead of only existing inside an in-process timer.
+var popDueDelayedEventsScript = redis.NewScript(`
+local due = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1])
+if #due > 0 then
+	redis.call('ZREM', KEYS[1], unpack(due))
+end
+return due
+`)
+
+// Event is the JSON payload delivered to a job's StatusHookURL whenever its
+// status transitions.
+type Event struct {
+	JobID   int64  `json:"job_id"`
+	Status  string `json:"status"`
+	Retries int    `json:"retries"`
+	Ts      int64  `json:"ts"`
+}
+
+// entry is what actually sits on the Redis lists: the event plus enough
+// delivery bookkeeping to resume after a retry or a crash.
+type entry struct {
+	URL     string `json:"url"`
+	Event   Event  `json:"event"`
+	Attempt int    `json:"attempt"`
+}
+
+// Push queues a status-change event for delivery to url. It is a no-op if
+// url is empty, since most jobs don't register a status hook.
+func Push(ctx context.Context, client *redis.Client, url string, event Event) error {
+	if url == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(entry{URL: url, Event: event})
+	if err != nil {
+		return err
+	}
+
+	return client.LPush(ctx, eventKeyPrefix+strconv.FormatInt(event.JobID, 10), data).Err()
+}
+
+// Pool delivers queued hook events with retry and exponential backoff,
+// parking permanently-failing events in hook_dead_events.
+type Pool struct {
+	client  *redis.Client
+	ctx     context.Context
+	workers int
+	timeout time.Duration
+}
+
+// NewPool creates a hook delivery pool of the given worker count; timeout
+// bounds each individual HTTP callback.
+func NewPool(client *redis.Client, workers int, timeout time.Duration) *Pool {
+	return &Pool{client: client, ctx: context.Background(), workers: workers, timeout: timeout}
+}
+
+// Start replays any events orphaned by a previous crash, then launches the
+// worker pool. Safe to call once per process.
+func (p *Pool) Start() {
+	p.reapOrphanedEvents()
+
+	go p.runDelayedRetries()
+
+	for i := 0; i < p.workers; i++ {
+		go p.worker()
+	}
+}
+
+func (p *Pool) worker() {
+	for {
+		processingKey, raw, ok := p.claimNext()
+		if !ok {
+			time.Sleep(250 * time.Millisecond)
+			continue
+		}
+		p.deliver(processingKey, raw)
+	}
+}
+
+// claimNext scans for a job with a pending hook event and atomically moves
+// it onto a per-job processing list, so a worker that dies mid-delivery
+// doesn't silently lose the event.
+func (p *Pool) claimNext() (processingKey, raw string, ok bool) {
+	iter := p.client.Scan(p.ctx, 0, eventKeyPrefix+"*", 100).Iterator()
+	for iter.Next(p.ctx) {
+		key := iter.Val()
+		procKey := processingKeyPrefix + strings.TrimPrefix(key, eventKeyPrefix)
+
+		val, err := p.client.RPopLPush(p.ctx, key, procKey).Result()
+		if err != nil {
+			continue
+		}
+		return procKey, val, true
+	}
+	return "", "", false
+}
+
+func (p *Pool) deliver(processingKey, raw string) {
+	var e entry
+	if err := json.Unmarshal([]byte(raw), &e); err != nil {
+		p.client.LRem(p.ctx, processingKey, 1, raw)
+		return
+	}
+
+	body, _ := json.Marshal(e.Event)
+	httpClient := &http.Client{Timeout: p.timeout}
+	resp, err := httpClient.Post(e.URL, "application/json", bytes.NewReader(body))
+
+	success := err == nil && resp.StatusCode >= 200 && resp.StatusCode < 300
+	if resp != nil {
+		resp.Body.Close()
+	}
+
+	p.client.LRem(p.ctx, processingKey, 1, raw)
+
+	if success {
+		return
+	}
+
+	e.Attempt++
+	if e.Attempt >= maxAttempts {
+		dead, _ := json.Marshal(e)
+		p.client.LPush(p.ctx, deadEventsKey, dead)
+		fmt.Printf("🪝 hook event for job #%d permanently failed after %d attempts, parked in %s\n", e.Event.JobID, e.Attempt, deadEventsKey)
+		return
+	}
+
+	backoff := time.Duration(1<<e.Attempt) * time.Second
+	updated, _ := json.Marshal(e)
+	p.client.ZAdd(p.ctx, delayedEventsKey, redis.Z{
+		Score:  float64(time.Now().Add(backoff).Unix()),
+		Member: updated,
+	})
+}
+
+// runDelayedRetries polls delayedEventsKey for retries whose backoff has
+// elapsed and pushes them back onto their job's hook_events:<id> list for a
+// worker to pick up. Because the pending retry lives in Redis the whole
+// time (not just in an in-process timer), a crash during the backoff
+// window can't lose it.
+func (p *Pool) runDelayedRetries() {
+	ticker := time.NewTicker(1 * time.Second)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		p.releaseDueDelayedEvents()
+	}
+}
+
+func (p *Pool) releaseDueDelayedEvents() {
+	due, err := popDueDelayedEventsScript.Run(p.ctx, p.client, []string{delayedEventsKey}, time.Now().Unix()).StringSlice()
+	if err != nil {
+		return
+	}
+
+	for _, raw := range due {
+		var e entry
+		if err := json.Unmarshal([]byte(raw), &e); err != nil {
+			continue
+		}
+		eventsKey := eventKeyPrefix + strconv.FormatInt(e.Event.JobID, 10)
+		p.client.LPush(p.ctx, eventsKey, raw)
+	}
+}
+
+// reapOrphanedEvents moves any event left on a hook_processing:* list back
+// onto its hook_events:<id> list. Those are events a worker claimed but
+// never finished delivering, most likely because the process crashed.
+func (p *Pool) reapOrphanedEvents() {
+	iter := p.client.Scan(p.ctx, 0, processingKeyPrefix+"*", 100).Iterator()
+	for iter.Next(p.ctx) {
+		procKey := iter.Val()
+		eventsKey := eventKeyPrefix + strings.TrimPrefix(procKey, processingKeyPrefix)
+
+		for {
+			_, err := p.client.RPopLPush(p.ctx, procKey, eventsKey).Result()
+			if err != nil {
+				break
+			}
+		}
+	}
+}
+
+// ListDeadEvents returns up to n decoded dead-letter events for the admin
+// UI; entries that fail to decode are skipped.
+func ListDeadEvents(ctx context.Context, client *redis.Client, n int64) ([]Event, error) {
+	raw, err := client.LRange(ctx, deadEventsKey, 0, n-1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	var out []Event
+	for _, s := range raw {
+		var e entry
+		if err := json.Unmarshal([]byte(s), &e); err == nil {
+			out = append(out, e.Event)
+		}
+	}
+	return out, nil
+}
@@ -1,12 +1,11 @@
 package httpui
 
 import (
-	"encoding/json"
 	"html/template"
 	"net/http"
 	"strconv"
 
-	"github.com/devang/go-task-queue/internal/metrics"
+	"github.com/devang/go-task-queue/internal/hook"
 	"github.com/devang/go-task-queue/internal/queue"
 )
 
@@ -32,7 +31,7 @@ var adminTmpl = template.Must(template.New("admin").Parse(`
           <div class="table-responsive">
             <table class="table table-sm align-middle">
               <thead><tr>
-                <th>ID</th><th>Name</th><th>Status</th><th>Retries</th><th>Started</th><th>Ended</th>
+                <th>ID</th><th>Name</th><th>Status</th><th>Retries</th><th>Started</th><th>Ended</th><th>Result</th>
               </tr></thead>
               <tbody>
               {{range .Recent}}
@@ -45,9 +44,12 @@ var adminTmpl = template.Must(template.New("admin").Parse(`
                   <td>{{.Retries}}</td>
                   <td>{{.StartedAt.Format "15:04:05"}}</td>
                   <td>{{.EndedAt.Format "15:04:05"}}</td>
+                  <td>
+                    {{if .Result}}<a href="/result?id={{.ID}}" class="badge bg-info text-dark text-decoration-none">stored{{if .Retention}} ({{.Retention}}){{end}}</a>{{else}}<span class="text-muted">&mdash;</span>{{end}}
+                  </td>
                 </tr>
               {{else}}
-                <tr><td colspan="6" class="text-muted">No recent jobs.</td></tr>
+                <tr><td colspan="7" class="text-muted">No recent jobs.</td></tr>
               {{end}}
               </tbody>
             </table>
@@ -72,8 +74,8 @@ var adminTmpl = template.Must(template.New("admin").Parse(`
                   <td>{{.Name}}</td>
                   <td>{{.Retries}}</td>
 				  <td>
-    				<a href="/dlq/retry?id={{.ID}}" class="btn btn-outline-primary btn-sm">Retry</a>
-   				 	<a href="/dlq/delete?id={{.ID}}" class="btn btn-outline-danger btn-sm">Delete</a>
+    				<a href="/dlq/retry?id={{.ID}}&queue={{.Queue}}" class="btn btn-outline-primary btn-sm">Retry</a>
+   				 	<a href="/dlq/delete?id={{.ID}}&queue={{.Queue}}" class="btn btn-outline-danger btn-sm">Delete</a>
   				  </td>
                 </tr>
               {{else}}
@@ -87,19 +89,160 @@ var adminTmpl = template.Must(template.New("admin").Parse(`
     </div>
   </div>
 
+  <div class="row g-4 mt-1">
+    <div class="col-12">
+      <div class="card shadow-sm">
+        <div class="card-body">
+          <h5 class="card-title">Periodic Jobs</h5>
+          <div class="table-responsive">
+            <table class="table table-sm align-middle">
+              <thead><tr>
+                <th>ID</th><th>Name</th><th>Spec</th><th>Next Run</th><th></th>
+              </tr></thead>
+              <tbody>
+              {{range .Periodic}}
+                <tr>
+                  <td>{{.ID}}</td>
+                  <td>{{.Name}}</td>
+                  <td><code>{{.Spec}}</code></td>
+                  <td>{{.NextRun.Format "2006-01-02 15:04:05"}}</td>
+                  <td><a href="/periodic/delete?id={{.ID}}" class="btn btn-outline-danger btn-sm">Delete</a></td>
+                </tr>
+              {{else}}
+                <tr><td colspan="5" class="text-muted">No periodic policies.</td></tr>
+              {{end}}
+              </tbody>
+            </table>
+          </div>
+        </div>
+      </div>
+    </div>
+  </div>
+
+  <div class="row g-4 mt-1">
+    <div class="col-lg-6">
+      <div class="card shadow-sm">
+        <div class="card-body">
+          <h5 class="card-title">Chains</h5>
+          <div class="table-responsive">
+            <table class="table table-sm align-middle">
+              <thead><tr><th>ID</th><th></th></tr></thead>
+              <tbody>
+              {{range .Chains}}
+                <tr><td>{{.}}</td><td><a href="/chain?id={{.}}" target="_blank">view</a></td></tr>
+              {{else}}
+                <tr><td colspan="2" class="text-muted">No chains yet.</td></tr>
+              {{end}}
+              </tbody>
+            </table>
+          </div>
+        </div>
+      </div>
+    </div>
+
+    <div class="col-lg-6">
+      <div class="card shadow-sm">
+        <div class="card-body">
+          <h5 class="card-title">Groups</h5>
+          <div class="table-responsive">
+            <table class="table table-sm align-middle">
+              <thead><tr><th>ID</th><th></th></tr></thead>
+              <tbody>
+              {{range .Groups}}
+                <tr><td>{{.}}</td><td><a href="/group?id={{.}}" target="_blank">view</a></td></tr>
+              {{else}}
+                <tr><td colspan="2" class="text-muted">No groups yet.</td></tr>
+              {{end}}
+              </tbody>
+            </table>
+          </div>
+        </div>
+      </div>
+    </div>
+  </div>
+
+  <div class="row g-4 mt-1">
+    <div class="col-12">
+      <div class="card shadow-sm">
+        <div class="card-body">
+          <h5 class="card-title">Reaped Jobs</h5>
+          <div class="table-responsive">
+            <table class="table table-sm align-middle">
+              <thead><tr>
+                <th>ID</th><th>Name</th><th>Status</th><th>Retries</th><th></th>
+              </tr></thead>
+              <tbody>
+              {{range .Reaped}}
+                <tr>
+                  <td>#{{.ID}}</td>
+                  <td>{{.Name}}</td>
+                  <td>{{.Status}}</td>
+                  <td>{{.Retries}}</td>
+                  <td><span class="badge bg-secondary">reason: stale</span></td>
+                </tr>
+              {{else}}
+                <tr><td colspan="5" class="text-muted">No jobs have been reaped.</td></tr>
+              {{end}}
+              </tbody>
+            </table>
+          </div>
+        </div>
+      </div>
+    </div>
+  </div>
+
+  <div class="row g-4 mt-1">
+    <div class="col-12">
+      <div class="card shadow-sm">
+        <div class="card-body">
+          <h5 class="card-title">Dead Status Hooks</h5>
+          <div class="table-responsive">
+            <table class="table table-sm align-middle">
+              <thead><tr>
+                <th>Job ID</th><th>Status</th><th>Retries</th><th>When</th>
+              </tr></thead>
+              <tbody>
+              {{range .DeadHooks}}
+                <tr>
+                  <td>#{{.JobID}}</td>
+                  <td>{{.Status}}</td>
+                  <td>{{.Retries}}</td>
+                  <td>{{.Ts}}</td>
+                </tr>
+              {{else}}
+                <tr><td colspan="4" class="text-muted">No undelivered status hooks.</td></tr>
+              {{end}}
+              </tbody>
+            </table>
+          </div>
+        </div>
+      </div>
+    </div>
+  </div>
+
 </div>
 </body>
 </html>
 `))
 
-func RegisterAdminRoutes(mux *http.ServeMux, rq *queue.RedisQueue) {
+func RegisterAdminRoutes(mux *http.ServeMux, rq *queue.RedisQueue, queues []string) {
 	mux.HandleFunc("/admin", func(w http.ResponseWriter, r *http.Request) {
 		recent, _ := rq.ListRecentJobs(20)
-		dlq, _ := rq.ListDLQ(20)
+		dlq, _ := rq.ListDLQ(queues, 20)
+		periodic, _ := rq.ListPeriodic()
+		reaped, _ := rq.ListReaped(20)
+		deadHooks, _ := hook.ListDeadEvents(rq.Ctx(), rq.Client(), 20)
+		chains, _ := rq.ListChains(20)
+		groups, _ := rq.ListGroups(20)
 
 		_ = adminTmpl.Execute(w, map[string]any{
-			"Recent": recent,
-			"DLQ":    dlq,
+			"Recent":    recent,
+			"DLQ":       dlq,
+			"Periodic":  periodic,
+			"Reaped":    reaped,
+			"DeadHooks": deadHooks,
+			"Chains":    chains,
+			"Groups":    groups,
 		})
 	})
 }
@@ -114,31 +257,17 @@ func RegisterAdminActions(mux *http.ServeMux, rq *queue.RedisQueue) {
 		}
 
 		id, _ := strconv.ParseInt(idStr, 10, 64)
+		queueName := r.URL.Query().Get("queue")
+		if queueName == "" {
+			queueName = queue.DefaultQueueName
+		}
 
-		// Find the job in DLQ
-		rawJobs, _ := rq.Client().LRange(rq.Ctx(), "dead_letter_queue", 0, -1).Result()
-		for _, raw := range rawJobs {
-			var job queue.Job
-			_ = json.Unmarshal([]byte(raw), &job)
-
-			if job.ID == id {
-				// Remove from DLQ
-				rq.Client().LRem(rq.Ctx(), "dead_letter_queue", 1, raw)
-
-				// Reset retries and requeue
-				job.Retries = 0
-				rq.Enqueue(job)
-
-				// Update DLQ metric
-				size, _ := rq.Client().LLen(rq.Ctx(), "dead_letter_queue").Result()
-				metrics.DLQSize.Set(float64(size))
-
-				http.Redirect(w, r, "/admin", http.StatusSeeOther)
-				return
-			}
+		if err := rq.RetryFromDLQ(queueName, id); err != nil {
+			http.Error(w, "job not found", http.StatusNotFound)
+			return
 		}
 
-		http.Error(w, "job not found", http.StatusNotFound)
+		http.Redirect(w, r, "/admin", http.StatusSeeOther)
 	})
 
 	// Delete job permanently
@@ -150,24 +279,16 @@ func RegisterAdminActions(mux *http.ServeMux, rq *queue.RedisQueue) {
 		}
 
 		id, _ := strconv.ParseInt(idStr, 10, 64)
+		queueName := r.URL.Query().Get("queue")
+		if queueName == "" {
+			queueName = queue.DefaultQueueName
+		}
 
-		rawJobs, _ := rq.Client().LRange(rq.Ctx(), "dead_letter_queue", 0, -1).Result()
-		for _, raw := range rawJobs {
-			var job queue.Job
-			_ = json.Unmarshal([]byte(raw), &job)
-
-			if job.ID == id {
-				rq.Client().LRem(rq.Ctx(), "dead_letter_queue", 1, raw)
-
-				// Update DLQ metric
-				size, _ := rq.Client().LLen(rq.Ctx(), "dead_letter_queue").Result()
-				metrics.DLQSize.Set(float64(size))
-
-				http.Redirect(w, r, "/admin", http.StatusSeeOther)
-				return
-			}
+		if err := rq.DeleteFromDLQ(queueName, id); err != nil {
+			http.Error(w, "job not found", http.StatusNotFound)
+			return
 		}
 
-		http.Error(w, "job not found", http.StatusNotFound)
+		http.Redirect(w, r, "/admin", http.StatusSeeOther)
 	})
 }
@@ -0,0 +1,56 @@
+package httpui
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/devang/go-task-queue/internal/queue"
+)
+
+// RegisterPeriodicRoutes wires up the periodic-job scheduler endpoints.
+func RegisterPeriodicRoutes(mux *http.ServeMux, rq *queue.RedisQueue) {
+	mux.HandleFunc("/periodic/create", func(w http.ResponseWriter, r *http.Request) {
+		name := r.URL.Query().Get("name")
+		spec := r.URL.Query().Get("spec")
+		payload := r.URL.Query().Get("payload")
+		if name == "" || spec == "" {
+			http.Error(w, "missing name or spec", http.StatusBadRequest)
+			return
+		}
+
+		id, err := rq.SchedulePeriodic(name, spec, payload)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		fmt.Fprintf(w, "Periodic policy %s created\n", id)
+	})
+
+	mux.HandleFunc("/periodic/list", func(w http.ResponseWriter, r *http.Request) {
+		policies, err := rq.ListPeriodic()
+		if err != nil {
+			http.Error(w, "could not list periodic policies", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(policies)
+	})
+
+	mux.HandleFunc("/periodic/delete", func(w http.ResponseWriter, r *http.Request) {
+		id := r.URL.Query().Get("id")
+		if id == "" {
+			http.Error(w, "missing id", http.StatusBadRequest)
+			return
+		}
+
+		if err := rq.DeletePeriodic(id); err != nil {
+			http.Error(w, "could not delete periodic policy", http.StatusInternalServerError)
+			return
+		}
+
+		http.Redirect(w, r, "/admin", http.StatusSeeOther)
+	})
+}
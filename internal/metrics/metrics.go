@@ -47,8 +47,24 @@ var (
 		Name: "taskqueue_dlq_size",
 		Help: "Number of jobs in the dead letter queue",
 	})
+
+	InflightJobs = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "taskqueue_inflight_jobs",
+		Help: "Number of jobs currently claimed by a worker",
+	})
+
+	JobsReaped = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "taskqueue_jobs_reaped_total",
+		Help: "Total number of jobs recovered by the stale in-flight reaper",
+	})
+
+	EnqueuePipelineBatchSize = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "taskqueue_enqueue_pipeline_batch_size",
+		Help:    "Number of jobs written per pipelined enqueue flush",
+		Buckets: prometheus.LinearBuckets(1, 50, 10),
+	})
 )
 
 func Register() {
-	Registry.MustRegister(JobsEnqueued, JobsCompleted, JobsFailed, JobProcessingTime, JobProcessingTimeLatest, JobProcessingTimeAverage, QueueDepth, DLQSize)
+	Registry.MustRegister(JobsEnqueued, JobsCompleted, JobsFailed, JobProcessingTime, JobProcessingTimeLatest, JobProcessingTimeAverage, QueueDepth, DLQSize, InflightJobs, JobsReaped, EnqueuePipelineBatchSize)
 }
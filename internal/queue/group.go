@@ -0,0 +1,268 @@
+package queue
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// ChainStatus is the aggregated view of a chain returned by GetChain: the
+// jobs in order, each annotated with its current per-chain status.
+type ChainStatus struct {
+	ID   string
+	Jobs []Job
+}
+
+// GroupStatus is the aggregated view of a group returned by GetGroup.
+type GroupStatus struct {
+	ID      string
+	Jobs    []Job
+	Pending int64
+}
+
+// EnqueueChain submits jobs as a dependent chain: only the first job is
+// enqueued immediately, and each subsequent one is enqueued after the
+// previous reaches SUCCESS. If a job in the chain ends in FAILED, the rest
+// are marked SKIPPED and never run.
+func (rq *RedisQueue) EnqueueChain(jobs ...Job) (string, error) {
+	if len(jobs) == 0 {
+		return "", fmt.Errorf("enqueue chain: no jobs given")
+	}
+
+	seq, err := rq.client.Incr(rq.ctx, "chain_seq").Result()
+	if err != nil {
+		return "", err
+	}
+	chainID := strconv.FormatInt(seq, 10)
+	key := "chain:" + chainID
+
+	for i, job := range jobs {
+		job.ChainID = chainID
+		jobs[i] = job
+
+		data, err := json.Marshal(job)
+		if err != nil {
+			return "", err
+		}
+		if err := rq.client.RPush(rq.ctx, key, data).Err(); err != nil {
+			return "", err
+		}
+		rq.client.HSet(rq.ctx, key+":status", i, "PENDING")
+	}
+
+	rq.client.Set(rq.ctx, key+":cursor", 0, 0)
+	rq.client.HSet(rq.ctx, key+":status", 0, "QUEUED")
+	rq.client.LPush(rq.ctx, "chains", chainID)
+	rq.client.LTrim(rq.ctx, "chains", 0, 99)
+
+	if err := rq.Enqueue(jobs[0]); err != nil {
+		return "", err
+	}
+	rq.SetJobStatus(jobs[0], "QUEUED")
+
+	return chainID, nil
+}
+
+// advanceChain is called when a chained job reaches SUCCESS: it marks the
+// job's slot done and enqueues the next job in the chain, if any.
+func (rq *RedisQueue) advanceChain(job Job) {
+	if job.ChainID == "" {
+		return
+	}
+	key := "chain:" + job.ChainID
+
+	cursor, err := rq.client.Get(rq.ctx, key+":cursor").Int64()
+	if err != nil {
+		return
+	}
+	rq.client.HSet(rq.ctx, key+":status", cursor, "SUCCESS")
+
+	raw, err := rq.client.LRange(rq.ctx, key, 0, -1).Result()
+	if err != nil {
+		return
+	}
+
+	next := cursor + 1
+	if next >= int64(len(raw)) {
+		return
+	}
+
+	var nextJob Job
+	if err := json.Unmarshal([]byte(raw[next]), &nextJob); err != nil {
+		return
+	}
+
+	rq.client.Set(rq.ctx, key+":cursor", next, 0)
+	rq.client.HSet(rq.ctx, key+":status", next, "QUEUED")
+	if err := rq.Enqueue(nextJob); err == nil {
+		rq.SetJobStatus(nextJob, "QUEUED")
+	}
+}
+
+// failChain is called when a chained job reaches FAILED: the rest of the
+// chain is marked SKIPPED and never runs.
+func (rq *RedisQueue) failChain(job Job) {
+	if job.ChainID == "" {
+		return
+	}
+	key := "chain:" + job.ChainID
+
+	cursor, err := rq.client.Get(rq.ctx, key+":cursor").Int64()
+	if err != nil {
+		return
+	}
+	rq.client.HSet(rq.ctx, key+":status", cursor, "FAILED")
+
+	raw, err := rq.client.LRange(rq.ctx, key, 0, -1).Result()
+	if err != nil {
+		return
+	}
+	for i := cursor + 1; i < int64(len(raw)); i++ {
+		rq.client.HSet(rq.ctx, key+":status", i, "SKIPPED")
+	}
+}
+
+// GetChain returns the jobs in chainID along with each one's current status.
+func (rq *RedisQueue) GetChain(chainID string) (ChainStatus, error) {
+	key := "chain:" + chainID
+
+	raw, err := rq.client.LRange(rq.ctx, key, 0, -1).Result()
+	if err != nil {
+		return ChainStatus{}, err
+	}
+	if len(raw) == 0 {
+		return ChainStatus{}, fmt.Errorf("chain %s not found", chainID)
+	}
+
+	statuses, _ := rq.client.HGetAll(rq.ctx, key+":status").Result()
+
+	out := ChainStatus{ID: chainID}
+	for i, s := range raw {
+		var job Job
+		if err := json.Unmarshal([]byte(s), &job); err != nil {
+			continue
+		}
+		job.Status = statuses[strconv.Itoa(i)]
+		out.Jobs = append(out.Jobs, job)
+	}
+	return out, nil
+}
+
+// ListChains returns the IDs of the most recently created chains.
+func (rq *RedisQueue) ListChains(n int64) ([]string, error) {
+	return rq.client.LRange(rq.ctx, "chains", 0, n-1).Result()
+}
+
+// EnqueueGroup submits jobs as a fan-in group: all of them are enqueued
+// concurrently. Once every job in the group reaches a terminal state,
+// continuation (if non-nil) is enqueued as a follow-up job.
+func (rq *RedisQueue) EnqueueGroup(continuation *Job, jobs ...Job) (string, error) {
+	if len(jobs) == 0 {
+		return "", fmt.Errorf("enqueue group: no jobs given")
+	}
+
+	seq, err := rq.client.Incr(rq.ctx, "group_seq").Result()
+	if err != nil {
+		return "", err
+	}
+	groupID := strconv.FormatInt(seq, 10)
+	jobsKey := fmt.Sprintf("group:%s:jobs", groupID)
+	pendingKey := fmt.Sprintf("group:%s:pending", groupID)
+
+	for i, job := range jobs {
+		job.GroupID = groupID
+		jobs[i] = job
+
+		data, err := json.Marshal(job)
+		if err != nil {
+			return "", err
+		}
+		rq.client.RPush(rq.ctx, jobsKey, data)
+		rq.client.SAdd(rq.ctx, pendingKey, job.ID)
+	}
+
+	if continuation != nil {
+		data, err := json.Marshal(*continuation)
+		if err == nil {
+			rq.client.Set(rq.ctx, fmt.Sprintf("group:%s:continuation", groupID), data, 0)
+		}
+	}
+
+	rq.client.LPush(rq.ctx, "groups", groupID)
+	rq.client.LTrim(rq.ctx, "groups", 0, 99)
+
+	for _, job := range jobs {
+		if err := rq.Enqueue(job); err != nil {
+			return "", err
+		}
+		rq.SetJobStatus(job, "QUEUED")
+	}
+
+	return groupID, nil
+}
+
+// completeGroupMember is called whenever a job belonging to a group reaches
+// a terminal state. Once the group's pending set is empty, its optional
+// continuation job is enqueued.
+func (rq *RedisQueue) completeGroupMember(job Job) {
+	if job.GroupID == "" {
+		return
+	}
+	pendingKey := fmt.Sprintf("group:%s:pending", job.GroupID)
+	rq.client.SRem(rq.ctx, pendingKey, job.ID)
+
+	remaining, err := rq.client.SCard(rq.ctx, pendingKey).Result()
+	if err != nil || remaining > 0 {
+		return
+	}
+
+	contKey := fmt.Sprintf("group:%s:continuation", job.GroupID)
+	data, err := rq.client.Get(rq.ctx, contKey).Result()
+	if err != nil {
+		return
+	}
+
+	var cont Job
+	if err := json.Unmarshal([]byte(data), &cont); err != nil {
+		return
+	}
+
+	if err := rq.Enqueue(cont); err == nil {
+		rq.SetJobStatus(cont, "QUEUED")
+		fmt.Printf("🧩 group %s finished, enqueued continuation job #%d\n", job.GroupID, cont.ID)
+	}
+}
+
+// GetGroup returns the jobs in groupID along with their current status and
+// how many are still pending.
+func (rq *RedisQueue) GetGroup(groupID string) (GroupStatus, error) {
+	jobsKey := fmt.Sprintf("group:%s:jobs", groupID)
+
+	raw, err := rq.client.LRange(rq.ctx, jobsKey, 0, -1).Result()
+	if err != nil {
+		return GroupStatus{}, err
+	}
+	if len(raw) == 0 {
+		return GroupStatus{}, fmt.Errorf("group %s not found", groupID)
+	}
+
+	pending, _ := rq.client.SCard(rq.ctx, fmt.Sprintf("group:%s:pending", groupID)).Result()
+
+	out := GroupStatus{ID: groupID, Pending: pending}
+	for _, s := range raw {
+		var job Job
+		if err := json.Unmarshal([]byte(s), &job); err != nil {
+			continue
+		}
+		if status, err := rq.GetJobStatus(job.ID); err == nil {
+			job.Status = status
+		}
+		out.Jobs = append(out.Jobs, job)
+	}
+	return out, nil
+}
+
+// ListGroups returns the IDs of the most recently created groups.
+func (rq *RedisQueue) ListGroups(n int64) ([]string, error) {
+	return rq.client.LRange(rq.ctx, "groups", 0, n-1).Result()
+}
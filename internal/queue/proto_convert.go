@@ -0,0 +1,66 @@
+package queue
+
+import (
+	"time"
+
+	pb "github.com/devang/go-task-queue/proto"
+)
+
+// toProto converts a Job to its wire representation. See proto/job.proto for
+// why fields 10+ exist alongside the core asynq-style schema.
+func toProto(job Job) *pb.Job {
+	return &pb.Job{
+		Id:               job.ID,
+		Name:             job.Name,
+		Payload:          []byte(job.Payload),
+		Retries:          int32(job.Retries),
+		MaxRetry:         int32(job.MaxRetry),
+		Status:           job.Status,
+		StartedAt:        unixOrZero(job.StartedAt),
+		EndedAt:          unixOrZero(job.EndedAt),
+		Deadline:         unixOrZero(job.Deadline),
+		Queue:            job.Queue,
+		RetentionSeconds: int64(job.Retention.Seconds()),
+		CompletedAt:      unixOrZero(job.CompletedAt),
+		Result:           job.Result,
+		StatusHookUrl:    job.StatusHookURL,
+		ChainId:          job.ChainID,
+		GroupId:          job.GroupID,
+	}
+}
+
+// fromProto reconstructs a Job from its wire representation.
+func fromProto(m *pb.Job) Job {
+	return Job{
+		ID:            m.Id,
+		Name:          m.Name,
+		Queue:         m.Queue,
+		Retries:       int(m.Retries),
+		MaxRetry:      int(m.MaxRetry),
+		Status:        m.Status,
+		StartedAt:     timeOrZero(m.StartedAt),
+		EndedAt:       timeOrZero(m.EndedAt),
+		Deadline:      timeOrZero(m.Deadline),
+		Retention:     time.Duration(m.RetentionSeconds) * time.Second,
+		CompletedAt:   timeOrZero(m.CompletedAt),
+		Result:        m.Result,
+		Payload:       string(m.Payload),
+		StatusHookURL: m.StatusHookUrl,
+		ChainID:       m.ChainId,
+		GroupID:       m.GroupId,
+	}
+}
+
+func unixOrZero(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+func timeOrZero(sec int64) time.Time {
+	if sec == 0 {
+		return time.Time{}
+	}
+	return time.Unix(sec, 0)
+}
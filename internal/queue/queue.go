@@ -10,20 +10,60 @@ import (
 )
 
 type Job struct {
-	ID        int64     `json:"id"`
-	Name      string    `json:"name"`
-	Retries   int       `json:"retries"`
-	MaxRetry  int       `json:"max_retry"`
-	Status    string    `json:"status"`
-	StartedAt time.Time `json:"started_at"`
-	EndedAt   time.Time `json:"ended_at"`
+	ID            int64         `json:"id"`
+	Name          string        `json:"name"`
+	Queue         string        `json:"queue,omitempty"`
+	Retries       int           `json:"retries"`
+	MaxRetry      int           `json:"max_retry"`
+	Status        string        `json:"status"`
+	StartedAt     time.Time     `json:"started_at"`
+	EndedAt       time.Time     `json:"ended_at"`
+	Deadline      time.Time     `json:"deadline,omitempty"`
+	Retention     time.Duration `json:"retention"`
+	CompletedAt   time.Time     `json:"completed_at"`
+	Result        []byte        `json:"result,omitempty"`
+	Payload       string        `json:"payload,omitempty"`
+	StatusHookURL string        `json:"status_hook_url,omitempty"`
+	ChainID       string        `json:"chain_id,omitempty"`
+	GroupID       string        `json:"group_id,omitempty"`
 }
 
+// HandlerFunc does the actual work for a job. Handlers that want to persist
+// a result payload can write it through rw; the bytes are retained for
+// job.Retention (or forever, if zero).
+type HandlerFunc func(job Job, rw *ResultWriter) error
+
 type Queue struct {
 	JobChannel    chan Job
+	Handler       HandlerFunc
 	counter       int64
 	totalDuration float64
 	totalJobs     int
+	redisQueue    *RedisQueue
+}
+
+// AttachRedisQueue lets WaitForOngoingJobs flush rq's pipelined enqueue
+// buffer as part of a graceful shutdown.
+func (q *Queue) AttachRedisQueue(rq *RedisQueue) {
+	q.redisQueue = rq
+}
+
+// WaitForOngoingJobs is called during graceful shutdown, after the HTTP
+// server has stopped accepting new work. It flushes any jobs still
+// buffered by a pipelined RedisQueue so nothing enqueued right before
+// shutdown is lost. It does not wait for jobs that workers are currently
+// processing; it returns as soon as the flush is done.
+func (q *Queue) WaitForOngoingJobs() {
+	if q.redisQueue != nil {
+		q.redisQueue.FlushPipe()
+	}
+}
+
+// SetHandler registers the callback workers use to process a dequeued job.
+// If no handler is set, workers fall back to a simulated workload so the
+// queue remains runnable out of the box.
+func (q *Queue) SetHandler(h HandlerFunc) {
+	q.Handler = h
 }
 
 func NewQueue(bufferCapacity int) *Queue {
@@ -33,8 +73,22 @@ func NewQueue(bufferCapacity int) *Queue {
 	}
 }
 
+// Enqueue mints a job ID and hands name off for processing. When a
+// RedisQueue is attached (see AttachRedisQueue), the ID comes from its
+// shared NextJobID allocator so this job can never collide with one fired
+// by another producer, such as the periodic scheduler. Without an attached
+// RedisQueue, or if NextJobID returns an error, it falls back to an
+// in-process counter.
 func (q *Queue) Enqueue(name string) Job {
-	id := atomic.AddInt64(&q.counter, 1)
+	var id int64
+	if q.redisQueue != nil {
+		if allocated, err := q.redisQueue.NextJobID(); err == nil {
+			id = allocated
+		}
+	}
+	if id == 0 {
+		id = atomic.AddInt64(&q.counter, 1)
+	}
 	job := Job{ID: id, Name: name, MaxRetry: 3}
 	q.JobChannel <- job
 	fmt.Printf("✅ Enqueued job #%d: %s\n", job.ID, job.Name)
@@ -42,11 +96,11 @@ func (q *Queue) Enqueue(name string) Job {
 	return job
 }
 
-func (q *Queue) StartWorkerWithRedis(workerID int, rq *RedisQueue) {
+func (q *Queue) StartWorkerWithRedis(workerID int, rq *RedisQueue, queues []string) {
 	go func() {
 
 		for {
-			job, err := rq.Dequeue()
+			job, err := rq.Dequeue(queues)
 			if err != nil {
 				fmt.Println("Redis dequeue error:", err)
 				continue
@@ -54,58 +108,88 @@ func (q *Queue) StartWorkerWithRedis(workerID int, rq *RedisQueue) {
 
 			job.Status = "PROCESSING"
 			job.StartedAt = time.Now()
-			rq.SetJobStatus(job.ID, "PROCESSING")
+			rq.SetJobStatus(job, "PROCESSING")
 			rq.SaveJob(job)
+			rq.touchInflight(job, workerID)
 
 			fmt.Printf("👷 Worker %d started job #%d (%s)\n", workerID, job.ID, job.Name)
 
-			success := q.processJobWithTimeout(workerID, job, 3*time.Second)
+			stopHeartbeat := make(chan struct{})
+			go func() {
+				ticker := time.NewTicker(2 * time.Second)
+				defer ticker.Stop()
+				for {
+					select {
+					case <-ticker.C:
+						rq.touchInflight(job, workerID)
+					case <-stopHeartbeat:
+						return
+					}
+				}
+			}()
+
+			success := q.processJob(workerID, job, rq, 3*time.Second)
+			close(stopHeartbeat)
+			rq.clearInflight(job.ID)
 
 			if success {
 				job.Status = "SUCCESS"
 				job.EndedAt = time.Now()
-				rq.SetJobStatus(job.ID, "SUCCESS")
+				job.CompletedAt = job.EndedAt
+				rq.SetJobStatus(job, "SUCCESS")
 				rq.SaveJob(job)
+				rq.advanceChain(job)
+				rq.completeGroupMember(job)
 				metrics.JobsCompleted.Inc()
 			} else if job.Retries < job.MaxRetry {
 				job.Retries++
 				backoff := time.Duration(5*(1<<job.Retries)) * time.Second // Exponential backoff
 				rq.EnqueueWithDelay(job, backoff)
 				job.Status = "RETRYING"
-				rq.SetJobStatus(job.ID, "RETRYING")
+				rq.SetJobStatus(job, "RETRYING")
 				rq.SaveJob(job)
 				fmt.Printf("🔁 Retrying job #%d (retry %d) after %.0f seconds\n", job.ID, job.Retries, backoff.Seconds())
 			} else {
 				job.Status = "FAILED"
 				job.EndedAt = time.Now()
 				rq.MoveToDLQ(job)
-				rq.SetJobStatus(job.ID, "FAILED")
+				rq.SetJobStatus(job, "FAILED")
 				rq.SaveJob(job)
+				rq.failChain(job)
+				rq.completeGroupMember(job)
 				metrics.JobsFailed.Inc()
 			}
 		}
 	}()
 }
 
-func (q *Queue) processJobWithTimeout(workerID int, job Job, timeout time.Duration) bool {
+// processJob runs the registered Handler (or a simulated workload if none is
+// set) for job, giving it a ResultWriter scoped to job.ID and job.Retention.
+func (q *Queue) processJob(workerID int, job Job, rq *RedisQueue, timeout time.Duration) bool {
 	start := time.Now() // start timer
 
-	done := make(chan bool, 1)
+	done := make(chan error, 1)
+	rw := &ResultWriter{rq: rq, jobID: job.ID, retention: job.Retention}
 
 	// Run job in a goroutine
 	go func() {
+		if q.Handler != nil {
+			done <- q.Handler(job, rw)
+			return
+		}
+
 		workTime := time.Duration(rand.Intn(4)+1) * time.Second
 		time.Sleep(workTime)
 
 		if rand.Float32() < 0.3 {
-			done <- false
+			done <- fmt.Errorf("simulated failure")
 			return
 		}
-		done <- true
+		done <- nil
 	}()
 
 	select {
-	case success := <-done:
+	case err := <-done:
 		duration := time.Since(start).Seconds()
 		metrics.JobProcessingTime.Observe(duration)
 		metrics.JobProcessingTimeLatest.Set(duration)
@@ -114,12 +198,12 @@ func (q *Queue) processJobWithTimeout(workerID int, job Job, timeout time.Durati
 		q.totalJobs++
 		metrics.JobProcessingTimeAverage.Set(q.totalDuration / float64(q.totalJobs))
 
-		if success {
+		if err == nil {
 			fmt.Printf("✅ Worker %d finished job #%d successfully in %.2f sec\n", workerID, job.ID, duration)
 			return true
 		}
 
-		fmt.Printf("❌ Worker %d failed job #%d in %.2f sec\n", workerID, job.ID, duration)
+		fmt.Printf("❌ Worker %d failed job #%d in %.2f sec: %v\n", workerID, job.ID, duration, err)
 		return false
 
 	case <-time.After(timeout):
@@ -7,18 +7,77 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/devang/go-task-queue/internal/hook"
 	"github.com/devang/go-task-queue/internal/metrics"
+	pb "github.com/devang/go-task-queue/proto"
+	"github.com/golang/protobuf/proto"
 	"github.com/redis/go-redis/v9"
 )
 
+// DefaultQueueName is used for jobs that don't set Job.Queue explicitly.
+const DefaultQueueName = "default"
+
+// jobIDSeqKey backs NextJobID, the single counter every job-producing path
+// (the /enqueue HTTP handler via Queue.Enqueue, and the periodic scheduler)
+// draws IDs from, so two producers can never mint the same ID for the same
+// queue.
+const jobIDSeqKey = "job_id_seq"
+
+// NextJobID allocates the next globally-unique job ID.
+func (rq *RedisQueue) NextJobID() (int64, error) {
+	return rq.client.Incr(rq.ctx, jobIDSeqKey).Result()
+}
+
+// Per-queue keys are hash-tagged (taskqueue:{<queue>}:...) so every key for a
+// given queue lands on the same Redis Cluster slot.
+func pendingKey(queueName string) string {
+	return fmt.Sprintf("taskqueue:{%s}:pending", queueName)
+}
+
+func taskKey(queueName string, id int64) string {
+	return fmt.Sprintf("taskqueue:{%s}:t:%d", queueName, id)
+}
+
+func dlqKey(queueName string) string {
+	return fmt.Sprintf("taskqueue:{%s}:dlq", queueName)
+}
+
+func delayedKey(queueName string) string {
+	return fmt.Sprintf("taskqueue:{%s}:delayed", queueName)
+}
+
+// Options configures the optional pipelined-enqueue path. A zero Options
+// leaves Enqueue issuing one LPush per call, as before.
+type Options struct {
+	// PipePeriod, if non-zero, buffers jobs passed to Enqueue in memory and
+	// flushes them to Redis in a single pipeline (one LPush per queue) on this interval
+	// (or sooner, once PipeMaxSize is reached).
+	PipePeriod time.Duration
+	// PipeMaxSize caps how many buffered jobs trigger an early flush.
+	PipeMaxSize int
+}
+
 type RedisQueue struct {
 	client *redis.Client
 	ctx    context.Context
+
+	pipeMu      sync.Mutex
+	pipeBuf     []Job
+	pipePeriod  time.Duration
+	pipeMaxSize int
 }
 
 func NewRedisQueue() *RedisQueue {
+	return NewRedisQueueWithOptions(Options{})
+}
+
+// NewRedisQueueWithOptions builds a RedisQueue like NewRedisQueue, plus an
+// optional background-flush pipeline for high-throughput producers. See
+// Options.
+func NewRedisQueueWithOptions(opts Options) *RedisQueue {
 	rawURL := os.Getenv("REDIS_ADDR")
 	if rawURL == "" {
 		rawURL = "redis://localhost:6379" // fallback for local dev
@@ -54,81 +113,296 @@ func NewRedisQueue() *RedisQueue {
 	})
 
 	fmt.Println("✅ Redis client initialized with:", addr)
-	return &RedisQueue{
-		client: rdb,
-		ctx:    context.Background(),
+
+	rq := &RedisQueue{
+		client:      rdb,
+		ctx:         context.Background(),
+		pipePeriod:  opts.PipePeriod,
+		pipeMaxSize: opts.PipeMaxSize,
 	}
+
+	if rq.pipePeriod > 0 {
+		go rq.runPipeFlusher()
+	}
+
+	return rq
 }
 
-func (rq *RedisQueue) EnqueueWithDelay(job Job, delay time.Duration) error {
-	jobData, err := json.Marshal(job)
+// saveTask proto-encodes job into its per-queue task hash, which is the
+// payload Dequeue/loadTask read back.
+func (rq *RedisQueue) saveTask(job Job) error {
+	data, err := proto.Marshal(toProto(job))
+	if err != nil {
+		return err
+	}
+	return rq.client.HSet(rq.ctx, taskKey(job.Queue, job.ID), map[string]interface{}{
+		"msg":      data,
+		"deadline": unixOrZero(job.Deadline),
+		"status":   job.Status,
+	}).Err()
+}
+
+// loadTask reads and decodes the task hash written by saveTask.
+func (rq *RedisQueue) loadTask(queueName string, id int64) (Job, error) {
+	raw, err := rq.client.HGet(rq.ctx, taskKey(queueName, id), "msg").Result()
 	if err != nil {
+		return Job{}, err
+	}
+	var m pb.Job
+	if err := proto.Unmarshal([]byte(raw), &m); err != nil {
+		return Job{}, err
+	}
+	return fromProto(&m), nil
+}
+
+func (rq *RedisQueue) EnqueueWithDelay(job Job, delay time.Duration) error {
+	if job.Queue == "" {
+		job.Queue = DefaultQueueName
+	}
+	if err := rq.saveTask(job); err != nil {
 		return err
 	}
 
 	execTime := time.Now().Add(delay).Unix()
 
-	// Add job to a sorted set with its execution timestamp as the score
-	return rq.client.ZAdd(rq.ctx, "delayed_jobs", redis.Z{
+	// Add the job ID to a sorted set with its execution timestamp as the score
+	return rq.client.ZAdd(rq.ctx, delayedKey(job.Queue), redis.Z{
 		Score:  float64(execTime),
-		Member: jobData,
+		Member: job.ID,
 	}).Err()
 }
 
 func (rq *RedisQueue) Enqueue(job Job) error {
-	data, err := json.Marshal(job)
-	if err != nil {
+	if job.Queue == "" {
+		job.Queue = DefaultQueueName
+	}
+
+	if rq.pipePeriod > 0 {
+		rq.bufferForPipe(job)
+		return nil
+	}
+
+	return rq.enqueueNow(job)
+}
+
+func (rq *RedisQueue) enqueueNow(job Job) error {
+	if err := rq.saveTask(job); err != nil {
 		return err
 	}
 
-	depth, _ := rq.client.LLen(rq.ctx, "job_queue").Result()
-	metrics.QueueDepth.Set(float64(depth))
+	key := pendingKey(job.Queue)
+	depth, _ := rq.client.LLen(rq.ctx, key).Result()
+	metrics.QueueDepth.Set(float64(depth + 1))
 
-	return rq.client.LPush(rq.ctx, "job_queue", data).Err()
+	return rq.client.LPush(rq.ctx, key, job.ID).Err()
 }
 
-func (rq *RedisQueue) Dequeue() (Job, error) {
-	result, err := rq.client.BRPop(rq.ctx, 0, "job_queue").Result()
+// EnqueueBatch pushes jobs onto their pending lists with a single pipelined
+// LPush per queue, for producers that already batch their own writes. Jobs
+// may span multiple queues: each job's task hash is written under its own
+// Job.Queue, grouped the same way FlushPipe groups the background-flush
+// pipe, so a job's ID is never pushed onto a different queue's pending list
+// than the one its task hash was saved under.
+func (rq *RedisQueue) EnqueueBatch(jobs []Job) error {
+	if len(jobs) == 0 {
+		return nil
+	}
+
+	byQueue := make(map[string][]interface{})
+	for _, job := range jobs {
+		if job.Queue == "" {
+			job.Queue = DefaultQueueName
+		}
+		if err := rq.saveTask(job); err != nil {
+			return err
+		}
+		byQueue[job.Queue] = append(byQueue[job.Queue], job.ID)
+	}
+
+	pipe := rq.client.Pipeline()
+	depthCmds := make(map[string]*redis.IntCmd, len(byQueue))
+	for queueName, ids := range byQueue {
+		key := pendingKey(queueName)
+		pipe.LPush(rq.ctx, key, ids...)
+		depthCmds[queueName] = pipe.LLen(rq.ctx, key)
+	}
+
+	if _, err := pipe.Exec(rq.ctx); err != nil {
+		return err
+	}
+
+	var totalDepth int64
+	for _, cmd := range depthCmds {
+		totalDepth += cmd.Val()
+	}
+	metrics.QueueDepth.Set(float64(totalDepth))
+	metrics.EnqueuePipelineBatchSize.Observe(float64(len(jobs)))
+
+	return nil
+}
+
+func (rq *RedisQueue) bufferForPipe(job Job) {
+	rq.pipeMu.Lock()
+	rq.pipeBuf = append(rq.pipeBuf, job)
+	full := len(rq.pipeBuf) >= rq.pipeMaxSize
+	rq.pipeMu.Unlock()
+
+	if full {
+		rq.FlushPipe()
+	}
+}
+
+func (rq *RedisQueue) runPipeFlusher() {
+	ticker := time.NewTicker(rq.pipePeriod)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		rq.FlushPipe()
+	}
+}
+
+// FlushPipe writes any jobs buffered by the pipelined Enqueue path to Redis,
+// grouping the LPush per queue so QueueDepth stays accurate, rather than one
+// round trip per job. Safe to call even when nothing is buffered.
+func (rq *RedisQueue) FlushPipe() {
+	rq.pipeMu.Lock()
+	batch := rq.pipeBuf
+	rq.pipeBuf = nil
+	rq.pipeMu.Unlock()
+
+	if len(batch) == 0 {
+		return
+	}
+
+	byQueue := make(map[string][]interface{})
+	for _, job := range batch {
+		if err := rq.saveTask(job); err != nil {
+			continue
+		}
+		byQueue[job.Queue] = append(byQueue[job.Queue], job.ID)
+	}
+
+	pipe := rq.client.Pipeline()
+	depthCmds := make(map[string]*redis.IntCmd, len(byQueue))
+	for queueName, ids := range byQueue {
+		key := pendingKey(queueName)
+		pipe.LPush(rq.ctx, key, ids...)
+		depthCmds[queueName] = pipe.LLen(rq.ctx, key)
+	}
+
+	if _, err := pipe.Exec(rq.ctx); err != nil {
+		fmt.Println("⚠️  pipe flush failed:", err)
+		return
+	}
+
+	var totalDepth int64
+	for _, cmd := range depthCmds {
+		totalDepth += cmd.Val()
+	}
+	metrics.QueueDepth.Set(float64(totalDepth))
+	metrics.EnqueuePipelineBatchSize.Observe(float64(len(batch)))
+}
+
+// Dequeue blocks on the pending list of each queue in queues, in priority
+// order, and returns the next job whichever of them produces one first.
+func (rq *RedisQueue) Dequeue(queues []string) (Job, error) {
+	keys := make([]string, len(queues))
+	for i, q := range queues {
+		keys[i] = pendingKey(q)
+	}
+
+	result, err := rq.client.BRPop(rq.ctx, 0, keys...).Result()
 	if err != nil {
 		return Job{}, err
 	}
-	depth, _ := rq.client.LLen(rq.ctx, "job_queue").Result()
+
+	poppedKey, idStr := result[0], result[1]
+	queueName := queueNameFromPendingKey(poppedKey)
+
+	depth, _ := rq.client.LLen(rq.ctx, poppedKey).Result()
 	metrics.QueueDepth.Set(float64(depth))
 
-	var job Job
-	err = json.Unmarshal([]byte(result[1]), &job)
-	return job, err
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return Job{}, err
+	}
+
+	return rq.loadTask(queueName, id)
+}
+
+// queueNameFromPendingKey extracts "<queue>" out of "taskqueue:{<queue>}:pending".
+func queueNameFromPendingKey(key string) string {
+	name := strings.TrimPrefix(key, "taskqueue:{")
+	name = strings.TrimSuffix(name, "}:pending")
+	return name
 }
 
 func (rq *RedisQueue) MoveToDLQ(job Job) {
-	data, _ := json.Marshal(job)
-	rq.client.LPush(rq.ctx, "dead_letter_queue", data)
+	if job.Queue == "" {
+		job.Queue = DefaultQueueName
+	}
+	if err := rq.saveTask(job); err != nil {
+		return
+	}
+	rq.client.LPush(rq.ctx, dlqKey(job.Queue), job.ID)
 
 	// Update DLQ metric
-	size, _ := rq.client.LLen(rq.ctx, "dead_letter_queue").Result()
+	size, _ := rq.client.LLen(rq.ctx, dlqKey(job.Queue)).Result()
+	metrics.DLQSize.Set(float64(size))
+}
+
+// RetryFromDLQ removes jobID from queueName's DLQ, resets its retry count,
+// and re-enqueues it.
+func (rq *RedisQueue) RetryFromDLQ(queueName string, jobID int64) error {
+	job, err := rq.loadTask(queueName, jobID)
+	if err != nil {
+		return err
+	}
+
+	rq.client.LRem(rq.ctx, dlqKey(queueName), 1, jobID)
+	size, _ := rq.client.LLen(rq.ctx, dlqKey(queueName)).Result()
 	metrics.DLQSize.Set(float64(size))
+
+	job.Retries = 0
+	return rq.Enqueue(job)
 }
 
-func (rq *RedisQueue) StartDelayedJobPoller() {
+// DeleteFromDLQ permanently removes jobID from queueName's DLQ.
+func (rq *RedisQueue) DeleteFromDLQ(queueName string, jobID int64) error {
+	if err := rq.client.LRem(rq.ctx, dlqKey(queueName), 1, jobID).Err(); err != nil {
+		return err
+	}
+	size, _ := rq.client.LLen(rq.ctx, dlqKey(queueName)).Result()
+	metrics.DLQSize.Set(float64(size))
+	return nil
+}
+
+func (rq *RedisQueue) StartDelayedJobPoller(queues []string) {
 	go func() {
 		for {
 			now := float64(time.Now().Unix())
 
-			// Get all jobs whose score (execution time) <= current time
-			jobs, err := rq.client.ZRangeByScore(rq.ctx, "delayed_jobs", &redis.ZRangeBy{
-				Min: "0",
-				Max: fmt.Sprintf("%f", now),
-			}).Result()
-
-			if err != nil {
-				time.Sleep(1 * time.Second)
-				continue
-			}
-
-			for _, jobData := range jobs {
-				// Move job from delayed_jobs to main queue
-				rq.client.LPush(rq.ctx, "job_queue", jobData)
-				rq.client.ZRem(rq.ctx, "delayed_jobs", jobData)
+			for _, queueName := range queues {
+				key := delayedKey(queueName)
+
+				// Get all job IDs whose score (execution time) <= current time
+				ids, err := rq.client.ZRangeByScore(rq.ctx, key, &redis.ZRangeBy{
+					Min: "0",
+					Max: fmt.Sprintf("%f", now),
+				}).Result()
+				if err != nil {
+					continue
+				}
+
+				for _, idStr := range ids {
+					id, err := strconv.ParseInt(idStr, 10, 64)
+					if err != nil {
+						continue
+					}
+					// Move the job from the delayed set to its queue's pending list
+					rq.client.LPush(rq.ctx, pendingKey(queueName), id)
+					rq.client.ZRem(rq.ctx, key, idStr)
+				}
 			}
 
 			time.Sleep(1 * time.Second) // check every second
@@ -136,9 +410,16 @@ func (rq *RedisQueue) StartDelayedJobPoller() {
 	}()
 }
 
-func (rq *RedisQueue) SetJobStatus(jobID int64, status string) {
-	key := fmt.Sprintf("job_status:%d", jobID)
+func (rq *RedisQueue) SetJobStatus(job Job, status string) {
+	key := fmt.Sprintf("job_status:%d", job.ID)
 	rq.client.Set(rq.ctx, key, status, 0)
+
+	_ = hook.Push(rq.ctx, rq.client, job.StatusHookURL, hook.Event{
+		JobID:   job.ID,
+		Status:  status,
+		Retries: job.Retries,
+		Ts:      time.Now().Unix(),
+	})
 }
 
 func (rq *RedisQueue) GetJobStatus(jobID int64) (string, error) {
@@ -150,13 +431,15 @@ func (rq *RedisQueue) SaveJob(job Job) {
 	key := fmt.Sprintf("job:%d", job.ID)
 
 	rq.client.HSet(rq.ctx, key, map[string]interface{}{
-		"id":         job.ID,
-		"name":       job.Name,
-		"retries":    job.Retries,
-		"max_retry":  job.MaxRetry,
-		"status":     job.Status,
-		"started_at": job.StartedAt.Format(time.RFC3339),
-		"ended_at":   job.EndedAt.Format(time.RFC3339),
+		"id":           job.ID,
+		"name":         job.Name,
+		"retries":      job.Retries,
+		"max_retry":    job.MaxRetry,
+		"status":       job.Status,
+		"started_at":   job.StartedAt.Format(time.RFC3339),
+		"ended_at":     job.EndedAt.Format(time.RFC3339),
+		"retention":    int64(job.Retention.Seconds()),
+		"completed_at": job.CompletedAt.Format(time.RFC3339),
 	})
 
 	if job.Status == "SUCCESS" || job.Status == "FAILED" {
@@ -164,6 +447,30 @@ func (rq *RedisQueue) SaveJob(job Job) {
 	}
 }
 
+// ResultWriter lets a job handler persist a result payload for later
+// retrieval via GetResult or the /result HTTP endpoint. The payload is kept
+// for jobID's Retention, or forever if Retention is zero.
+type ResultWriter struct {
+	rq        *RedisQueue
+	jobID     int64
+	retention time.Duration
+}
+
+func (w *ResultWriter) Write(data []byte) error {
+	if w.rq == nil {
+		return fmt.Errorf("result writer: no redis queue configured")
+	}
+	key := fmt.Sprintf("job_result:%d", w.jobID)
+	return w.rq.client.Set(w.rq.ctx, key, data, w.retention).Err()
+}
+
+// GetResult returns the bytes a handler wrote for jobID via ResultWriter.
+// It returns redis.Nil if the result was never stored or has expired.
+func (rq *RedisQueue) GetResult(jobID int64) ([]byte, error) {
+	key := fmt.Sprintf("job_result:%d", jobID)
+	return rq.client.Get(rq.ctx, key).Bytes()
+}
+
 func (rq *RedisQueue) ListRecentJobs(n int64) ([]Job, error) {
 	ids, err := rq.client.LRange(rq.ctx, "job_history", 0, n-1).Result()
 	if err != nil {
@@ -189,22 +496,249 @@ func (rq *RedisQueue) ListRecentJobs(n int64) ([]Job, error) {
 		}
 		j.StartedAt, _ = time.Parse(time.RFC3339, h["started_at"])
 		j.EndedAt, _ = time.Parse(time.RFC3339, h["ended_at"])
+		j.CompletedAt, _ = time.Parse(time.RFC3339, h["completed_at"])
+		if secs, _ := strconv.Atoi(h["retention"]); secs > 0 {
+			j.Retention = time.Duration(secs) * time.Second
+		}
+		if j.Status == "SUCCESS" {
+			if data, err := rq.GetResult(j.ID); err == nil {
+				j.Result = data
+			}
+		}
 		out = append(out, j)
 	}
 	return out, nil
 }
 
-func (rq *RedisQueue) ListDLQ(n int64) ([]Job, error) {
-	raw, err := rq.client.LRange(rq.ctx, "dead_letter_queue", 0, n-1).Result()
+// ListDLQ returns up to n dead-lettered jobs, aggregated across queues.
+func (rq *RedisQueue) ListDLQ(queues []string, n int64) ([]Job, error) {
+	var out []Job
+	for _, queueName := range queues {
+		ids, err := rq.client.LRange(rq.ctx, dlqKey(queueName), 0, n-1).Result()
+		if err != nil {
+			continue
+		}
+		for _, idStr := range ids {
+			id, err := strconv.ParseInt(idStr, 10, 64)
+			if err != nil {
+				continue
+			}
+			if job, err := rq.loadTask(queueName, id); err == nil {
+				out = append(out, job)
+			}
+		}
+	}
+	return out, nil
+}
+
+// MigrateLegacySchema drains the pre-namespacing job_queue, dead_letter_queue
+// and delayed_jobs keys (JSON-encoded Job values, one global queue) into
+// DefaultQueueName under the current proto-encoded, per-queue schema. It is
+// safe to call on every startup: once the legacy keys are empty, it's a
+// no-op.
+func (rq *RedisQueue) MigrateLegacySchema() error {
+	for {
+		data, err := rq.client.RPop(rq.ctx, "job_queue").Result()
+		if err == redis.Nil {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		var job Job
+		if err := json.Unmarshal([]byte(data), &job); err != nil {
+			continue
+		}
+		job.Queue = DefaultQueueName
+		if err := rq.Enqueue(job); err != nil {
+			return err
+		}
+	}
+
+	for {
+		data, err := rq.client.RPop(rq.ctx, "dead_letter_queue").Result()
+		if err == redis.Nil {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		var job Job
+		if err := json.Unmarshal([]byte(data), &job); err != nil {
+			continue
+		}
+		job.Queue = DefaultQueueName
+		rq.MoveToDLQ(job)
+	}
+
+	members, err := rq.client.ZRangeWithScores(rq.ctx, "delayed_jobs", 0, -1).Result()
+	if err != nil && err != redis.Nil {
+		return err
+	}
+	for _, member := range members {
+		data, ok := member.Member.(string)
+		if !ok {
+			continue
+		}
+		var job Job
+		if err := json.Unmarshal([]byte(data), &job); err != nil {
+			continue
+		}
+		job.Queue = DefaultQueueName
+		delay := time.Unix(int64(member.Score), 0).Sub(time.Now())
+		if err := rq.EnqueueWithDelay(job, delay); err != nil {
+			return err
+		}
+		rq.client.ZRem(rq.ctx, "delayed_jobs", member.Member)
+	}
+
+	return nil
+}
+
+const inflightKey = "inflight"
+
+// inflightEntry tracks which worker owns a job and when it last proved it's
+// still alive, so the reaper can tell a slow job from an abandoned one.
+type inflightEntry struct {
+	WorkerID    int    `json:"worker_id"`
+	Queue       string `json:"queue"`
+	StartedAt   int64  `json:"started_at"`
+	HeartbeatTs int64  `json:"heartbeat_ts"`
+}
+
+// touchInflight records that workerID is (still) working job, refreshing
+// its heartbeat. Call it once when work starts and periodically while it's
+// in progress.
+func (rq *RedisQueue) touchInflight(job Job, workerID int) {
+	entry := inflightEntry{
+		WorkerID:    workerID,
+		Queue:       job.Queue,
+		StartedAt:   job.StartedAt.Unix(),
+		HeartbeatTs: time.Now().Unix(),
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	rq.client.HSet(rq.ctx, inflightKey, strconv.FormatInt(job.ID, 10), data)
+	rq.refreshInflightGauge()
+}
+
+// clearInflight removes a job from the in-flight hash once its worker has
+// finished an attempt at it (success, retry or failure), so the reaper no
+// longer has to consider it.
+func (rq *RedisQueue) clearInflight(jobID int64) {
+	rq.client.HDel(rq.ctx, inflightKey, strconv.FormatInt(jobID, 10))
+	rq.refreshInflightGauge()
+}
+
+func (rq *RedisQueue) refreshInflightGauge() {
+	size, err := rq.client.HLen(rq.ctx, inflightKey).Result()
+	if err == nil {
+		metrics.InflightJobs.Set(float64(size))
+	}
+}
+
+// StartReaper launches a goroutine that polls the in-flight hash every
+// interval and recovers jobs whose worker hasn't sent a heartbeat in
+// staleAfter, most likely because it crashed mid-job.
+func (rq *RedisQueue) StartReaper(interval, staleAfter time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			rq.reapStaleJobs(staleAfter)
+		}
+	}()
+}
+
+func (rq *RedisQueue) reapStaleJobs(staleAfter time.Duration) {
+	entries, err := rq.client.HGetAll(rq.ctx, inflightKey).Result()
+	if err != nil {
+		return
+	}
+
+	now := time.Now()
+	for idStr, raw := range entries {
+		var e inflightEntry
+		if err := json.Unmarshal([]byte(raw), &e); err != nil {
+			continue
+		}
+		if now.Sub(time.Unix(e.HeartbeatTs, 0)) <= staleAfter {
+			continue
+		}
+
+		id, err := strconv.ParseInt(idStr, 10, 64)
+		if err != nil {
+			continue
+		}
+		rq.reapJob(id, e)
+	}
+}
+
+// reapJob recovers a single stale job: retried if it still has attempts
+// left, otherwise moved straight to the DLQ like a normal exhausted retry.
+// It loads the job through the same loadTask path Dequeue/RetryFromDLQ use,
+// rather than the legacy job:<id> hash, so Payload, StatusHookURL, ChainID,
+// GroupID and Deadline all survive the reap instead of coming back empty.
+func (rq *RedisQueue) reapJob(jobID int64, e inflightEntry) {
+	queueName := e.Queue
+	if queueName == "" {
+		queueName = DefaultQueueName
+	}
+
+	job, err := rq.loadTask(queueName, jobID)
+	rq.clearInflight(jobID)
+	if err != nil {
+		return
+	}
+
+	if job.Retries >= job.MaxRetry {
+		job.Status = "FAILED"
+		job.EndedAt = time.Now()
+		rq.MoveToDLQ(job)
+		rq.SetJobStatus(job, "FAILED")
+		rq.failChain(job)
+		rq.completeGroupMember(job)
+	} else {
+		job.Retries++
+		job.Status = "RETRYING"
+		rq.Enqueue(job)
+		rq.SetJobStatus(job, "RETRYING")
+	}
+	rq.SaveJob(job)
+
+	rq.client.LPush(rq.ctx, "reaped_jobs", jobID)
+	rq.client.LTrim(rq.ctx, "reaped_jobs", 0, 99)
+	metrics.JobsReaped.Inc()
+
+	fmt.Printf("🧟 Reaped stale job #%d: worker %d hadn't sent a heartbeat since %s\n", jobID, e.WorkerID, time.Unix(e.HeartbeatTs, 0).Format(time.RFC3339))
+}
+
+// ListReaped returns the most recently reaped jobs for the admin UI.
+func (rq *RedisQueue) ListReaped(n int64) ([]Job, error) {
+	ids, err := rq.client.LRange(rq.ctx, "reaped_jobs", 0, n-1).Result()
 	if err != nil {
 		return nil, err
 	}
+
 	var out []Job
-	for _, s := range raw {
+	for _, s := range ids {
+		h, err := rq.client.HGetAll(rq.ctx, "job:"+s).Result()
+		if err != nil || len(h) == 0 {
+			continue
+		}
+
 		var j Job
-		if err := json.Unmarshal([]byte(s), &j); err == nil {
-			out = append(out, j)
+		if id, _ := strconv.ParseInt(h["id"], 10, 64); id != 0 {
+			j.ID = id
 		}
+		j.Name = h["name"]
+		j.Status = h["status"]
+		j.Retries, _ = strconv.Atoi(h["retries"])
+		out = append(out, j)
 	}
 	return out, nil
 }
@@ -0,0 +1,219 @@
+package queue
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/robfig/cron/v3"
+)
+
+const (
+	periodicPolicyKeyPrefix = "periodic:"
+	periodicPoliciesZSet    = "periodic_policies"
+	periodicLeaderKey       = "periodic_leader"
+	periodicPolicySeqKey    = "periodic_policy_seq"
+	periodicLeaderTTL       = 5 * time.Second
+
+	// periodicProvisionalRetryDelay is how far out claimPeriodicScript bumps
+	// a policy's score while its real next_run is being computed. If the
+	// process dies mid-fire, the policy reappears as due after this delay
+	// instead of being dropped from periodicPoliciesZSet forever.
+	periodicProvisionalRetryDelay = 60 * time.Second
+)
+
+var cronParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+
+// claimPeriodicScript atomically claims a due policy by setting its score
+// to ARGV[3] (a provisional next-run time), but only if its current score
+// is still <= ARGV[2] (i.e. it is still due). Using ZADD instead of ZREM means the
+// policy is never actually absent from the ZSET between the claim and the
+// real reschedule in firePeriodicPolicy: a crash in that window just means
+// it fires again after the provisional delay instead of silently vanishing.
+// This keeps at-most-one-enqueue-per-tick safe even if leadership changes
+// mid-poll.
+var claimPeriodicScript = redis.NewScript(`
+local score = redis.call('ZSCORE', KEYS[1], ARGV[1])
+if not score then
+	return 0
+end
+if tonumber(score) > tonumber(ARGV[2]) then
+	return 0
+end
+redis.call('ZADD', KEYS[1], ARGV[3], ARGV[1])
+return 1
+`)
+
+// PeriodicPolicy describes a recurring job registered with SchedulePeriodic.
+type PeriodicPolicy struct {
+	ID      string
+	Name    string
+	Spec    string
+	Payload string
+	NextRun time.Time
+}
+
+// SchedulePeriodic registers a recurring job driven by a standard cron spec
+// (e.g. "*/5 * * * *"). Every time the schedule fires, payload is enqueued
+// as a new Job named name through the normal Enqueue path.
+func (rq *RedisQueue) SchedulePeriodic(name, spec, payload string) (string, error) {
+	schedule, err := cronParser.Parse(spec)
+	if err != nil {
+		return "", fmt.Errorf("invalid cron spec %q: %w", spec, err)
+	}
+
+	seq, err := rq.client.Incr(rq.ctx, periodicPolicySeqKey).Result()
+	if err != nil {
+		return "", err
+	}
+	policyID := strconv.FormatInt(seq, 10)
+	next := schedule.Next(time.Now())
+
+	if err := rq.client.HSet(rq.ctx, periodicPolicyKeyPrefix+policyID, map[string]interface{}{
+		"spec":     spec,
+		"name":     name,
+		"payload":  payload,
+		"next_run": next.Unix(),
+	}).Err(); err != nil {
+		return "", err
+	}
+
+	if err := rq.client.ZAdd(rq.ctx, periodicPoliciesZSet, redis.Z{
+		Score:  float64(next.Unix()),
+		Member: policyID,
+	}).Err(); err != nil {
+		return "", err
+	}
+
+	return policyID, nil
+}
+
+// ListPeriodic returns every registered periodic policy, soonest-due first.
+func (rq *RedisQueue) ListPeriodic() ([]PeriodicPolicy, error) {
+	ids, err := rq.client.ZRange(rq.ctx, periodicPoliciesZSet, 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	var out []PeriodicPolicy
+	for _, id := range ids {
+		policy, err := rq.getPeriodicPolicy(id)
+		if err != nil {
+			continue
+		}
+		out = append(out, policy)
+	}
+	return out, nil
+}
+
+// DeletePeriodic removes a periodic policy so it no longer fires.
+func (rq *RedisQueue) DeletePeriodic(id string) error {
+	rq.client.ZRem(rq.ctx, periodicPoliciesZSet, id)
+	return rq.client.Del(rq.ctx, periodicPolicyKeyPrefix+id).Err()
+}
+
+func (rq *RedisQueue) getPeriodicPolicy(id string) (PeriodicPolicy, error) {
+	h, err := rq.client.HGetAll(rq.ctx, periodicPolicyKeyPrefix+id).Result()
+	if err != nil {
+		return PeriodicPolicy{}, err
+	}
+	if len(h) == 0 {
+		return PeriodicPolicy{}, fmt.Errorf("periodic policy %s not found", id)
+	}
+
+	policy := PeriodicPolicy{ID: id, Name: h["name"], Spec: h["spec"], Payload: h["payload"]}
+	if secs, err := strconv.ParseInt(h["next_run"], 10, 64); err == nil {
+		policy.NextRun = time.Unix(secs, 0)
+	}
+	return policy, nil
+}
+
+// StartPeriodicScheduler starts the leader-elected goroutine that polls due
+// periodic policies and enqueues their jobs. Safe to call from every app
+// replica: only the instance holding periodic_leader does any work.
+func (rq *RedisQueue) StartPeriodicScheduler() {
+	instanceID := fmt.Sprintf("%d-%d", os.Getpid(), time.Now().UnixNano())
+
+	go func() {
+		ticker := time.NewTicker(1 * time.Second)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if !rq.acquirePeriodicLeadership(instanceID) {
+				continue
+			}
+
+			now := time.Now()
+			due, err := rq.client.ZRangeByScore(rq.ctx, periodicPoliciesZSet, &redis.ZRangeBy{
+				Min: "0",
+				Max: strconv.FormatInt(now.Unix(), 10),
+			}).Result()
+			if err != nil {
+				continue
+			}
+
+			for _, id := range due {
+				rq.firePeriodicPolicy(id, now)
+			}
+		}
+	}()
+}
+
+func (rq *RedisQueue) acquirePeriodicLeadership(instanceID string) bool {
+	ok, err := rq.client.SetNX(rq.ctx, periodicLeaderKey, instanceID, periodicLeaderTTL).Result()
+	if err != nil {
+		return false
+	}
+	if ok {
+		return true
+	}
+
+	current, err := rq.client.Get(rq.ctx, periodicLeaderKey).Result()
+	if err != nil || current != instanceID {
+		return false
+	}
+
+	rq.client.Expire(rq.ctx, periodicLeaderKey, periodicLeaderTTL)
+	return true
+}
+
+func (rq *RedisQueue) firePeriodicPolicy(id string, now time.Time) {
+	provisionalNextRun := now.Add(periodicProvisionalRetryDelay).Unix()
+	claimed, err := claimPeriodicScript.Run(rq.ctx, rq.client, []string{periodicPoliciesZSet}, id, now.Unix(), provisionalNextRun).Int()
+	if err != nil || claimed == 0 {
+		return
+	}
+
+	policy, err := rq.getPeriodicPolicy(id)
+	if err != nil {
+		return
+	}
+
+	schedule, err := cronParser.Parse(policy.Spec)
+	if err != nil {
+		// claimPeriodicScript already bumped this policy's score to a
+		// provisional next-run, so it must be actually deleted here or it
+		// will keep re-claiming and re-failing to parse forever.
+		fmt.Println("⏰ periodic policy has invalid cron spec, dropping:", id, err)
+		rq.DeletePeriodic(id)
+		return
+	}
+
+	// Jobs fired by the scheduler draw from the same ID allocator as every
+	// other enqueue path (NextJobID), so a periodic job can never collide
+	// with a directly-enqueued one sharing the same queue.
+	jobID, err := rq.NextJobID()
+	if err == nil {
+		job := Job{ID: jobID, Name: policy.Name, Payload: policy.Payload, MaxRetry: 3}
+		if err := rq.Enqueue(job); err == nil {
+			rq.SetJobStatus(job, "QUEUED")
+			fmt.Printf("⏰ periodic policy %s (%s) fired job #%d\n", id, policy.Name, job.ID)
+		}
+	}
+
+	next := schedule.Next(now)
+	rq.client.HSet(rq.ctx, periodicPolicyKeyPrefix+id, "next_run", next.Unix())
+	rq.client.ZAdd(rq.ctx, periodicPoliciesZSet, redis.Z{Score: float64(next.Unix()), Member: id})
+}
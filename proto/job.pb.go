@@ -0,0 +1,36 @@
+// Package taskqueuepb is the wire representation for queue.Job, hand-written
+// to match proto/job.proto using the legacy (reflection-based)
+// github.com/golang/protobuf/proto encoder. There is no protoc-gen-go
+// invocation in this repo, so this file is NOT generated — if job.proto
+// changes, update the struct and tags here by hand to match.
+// source: proto/job.proto
+
+package taskqueuepb
+
+import (
+	proto "github.com/golang/protobuf/proto"
+)
+
+// Job is the wire representation of queue.Job. See proto/job.proto.
+type Job struct {
+	Id               int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name             string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Payload          []byte `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`
+	Retries          int32  `protobuf:"varint,4,opt,name=retries,proto3" json:"retries,omitempty"`
+	MaxRetry         int32  `protobuf:"varint,5,opt,name=max_retry,json=maxRetry,proto3" json:"max_retry,omitempty"`
+	Status           string `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	StartedAt        int64  `protobuf:"varint,7,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	EndedAt          int64  `protobuf:"varint,8,opt,name=ended_at,json=endedAt,proto3" json:"ended_at,omitempty"`
+	Deadline         int64  `protobuf:"varint,9,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	Queue            string `protobuf:"bytes,10,opt,name=queue,proto3" json:"queue,omitempty"`
+	RetentionSeconds int64  `protobuf:"varint,11,opt,name=retention_seconds,json=retentionSeconds,proto3" json:"retention_seconds,omitempty"`
+	CompletedAt      int64  `protobuf:"varint,12,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	Result           []byte `protobuf:"bytes,13,opt,name=result,proto3" json:"result,omitempty"`
+	StatusHookUrl    string `protobuf:"bytes,14,opt,name=status_hook_url,json=statusHookUrl,proto3" json:"status_hook_url,omitempty"`
+	ChainId          string `protobuf:"bytes,15,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	GroupId          string `protobuf:"bytes,16,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+}
+
+func (m *Job) Reset()         { *m = Job{} }
+func (m *Job) String() string { return proto.CompactTextString(m) }
+func (*Job) ProtoMessage()    {}